@@ -0,0 +1,77 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// maxFailedAttempts bounds the in-memory ring buffer of failed scheduling attempts served by the debug endpoint.
+const maxFailedAttempts = 200
+
+// FailedAttempt is a single pod-to-NodeClaim scheduling rejection, recorded for the debug endpoint.
+type FailedAttempt struct {
+	Time          time.Time        `json:"time"`
+	Pod           string           `json:"pod"`
+	PredicateName string           `json:"predicateName"`
+	Reason        string           `json:"reason"`
+	SchedulingErr *SchedulingError `json:"schedulingError,omitempty"`
+	NodeClaim     string           `json:"nodeClaim"`
+}
+
+var (
+	failedAttemptsMu sync.Mutex
+	failedAttempts   []FailedAttempt
+)
+
+func recordFailedAttempt(pod *v1.Pod, n *NodeClaim, fitErr *FitError) {
+	failedAttemptsMu.Lock()
+	defer failedAttemptsMu.Unlock()
+
+	attempt := FailedAttempt{
+		Time:          time.Now(),
+		Pod:           pod.Namespace + "/" + pod.Name,
+		PredicateName: fitErr.PredicateName,
+		Reason:        fitErr.Reason,
+		SchedulingErr: fitErr.SchedulingError,
+		NodeClaim:     n.String(),
+	}
+	failedAttempts = append(failedAttempts, attempt)
+	if len(failedAttempts) > maxFailedAttempts {
+		failedAttempts = failedAttempts[len(failedAttempts)-maxFailedAttempts:]
+	}
+}
+
+// LastFailedAttempts returns up to the last maxFailedAttempts pod-to-NodeClaim scheduling rejections, most recent
+// last.
+func LastFailedAttempts() []FailedAttempt {
+	failedAttemptsMu.Lock()
+	defer failedAttemptsMu.Unlock()
+	return append([]FailedAttempt(nil), failedAttempts...)
+}
+
+// DebugHandler serves the last recorded failed scheduling attempts as JSON. It is served only on a mux passed to
+// operator.RegisterDebugEndpoints, not exposed on the metrics port.
+func DebugHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(LastFailedAttempts()); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
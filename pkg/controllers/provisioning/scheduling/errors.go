@@ -0,0 +1,78 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"fmt"
+
+	"github.com/samber/lo"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/aws/karpenter-core/pkg/cloudprovider"
+)
+
+// SchedulingError aggregates why every candidate instance type was rejected when filterInstanceTypesByRequirements
+// returns no survivors, so callers can inspect why a pod failed instead of regex-parsing a message.
+type SchedulingError struct {
+	// Incompatible is the number of instance types rejected for having incompatible requirements.
+	Incompatible int
+	// InsufficientResource is the number of instance types rejected for not having enough allocatable resources.
+	InsufficientResource int
+	// NoOffering is the number of instance types rejected for having no available offering in a compatible zone
+	// and capacity type.
+	NoOffering int
+
+	// IncompatibleKeys lists the requirement keys that caused at least one instance type to be rejected.
+	IncompatibleKeys []string
+	// MissingResources is the largest per-resource shortfall observed across instance types rejected for resources.
+	MissingResources v1.ResourceList
+	// UnavailableOfferings lists the offerings instance types had that didn't satisfy the zone/capacity-type
+	// requirements.
+	UnavailableOfferings []cloudprovider.Offering
+}
+
+func (e *SchedulingError) Error() string {
+	if e == nil {
+		return ""
+	}
+	return fmt.Sprintf("%d incompatible requirements %v, %d insufficient resource(s) %s, %d with no offerings %v",
+		e.Incompatible, e.IncompatibleKeys, e.InsufficientResource, resourceListString(e.MissingResources), e.NoOffering, e.UnavailableOfferings)
+}
+
+func resourceListString(rl v1.ResourceList) string {
+	return fmt.Sprintf("%v", rl)
+}
+
+func (e *SchedulingError) recordIncompatible(keys ...string) {
+	e.Incompatible++
+	e.IncompatibleKeys = lo.Uniq(append(e.IncompatibleKeys, keys...))
+}
+
+func (e *SchedulingError) recordInsufficientResource(missing v1.ResourceList) {
+	e.InsufficientResource++
+	if e.MissingResources == nil {
+		e.MissingResources = v1.ResourceList{}
+	}
+	for name, qty := range missing {
+		if existing, ok := e.MissingResources[name]; !ok || qty.Cmp(existing) > 0 {
+			e.MissingResources[name] = qty
+		}
+	}
+}
+
+func (e *SchedulingError) recordNoOffering(offerings []cloudprovider.Offering) {
+	e.NoOffering++
+	e.UnavailableOfferings = append(e.UnavailableOfferings, offerings...)
+}
@@ -0,0 +1,92 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/aws/karpenter-core/pkg/cloudprovider"
+)
+
+func TestSchedulingErrorRecordIncompatible(t *testing.T) {
+	e := &SchedulingError{}
+	e.recordIncompatible("node.kubernetes.io/instance-type")
+	e.recordIncompatible("topology.kubernetes.io/zone")
+
+	if e.Incompatible != 2 {
+		t.Fatalf("Incompatible = %d, want 2", e.Incompatible)
+	}
+	// recordIncompatible should only ever accumulate the keys actually passed to it, deduplicated, not every key
+	// in whatever requirement set the caller happened to be checking.
+	if len(e.IncompatibleKeys) != 2 {
+		t.Fatalf("IncompatibleKeys = %v, want 2 distinct keys", e.IncompatibleKeys)
+	}
+
+	e.recordIncompatible("topology.kubernetes.io/zone")
+	if len(e.IncompatibleKeys) != 2 {
+		t.Fatalf("IncompatibleKeys should dedupe repeated keys, got %v", e.IncompatibleKeys)
+	}
+}
+
+func TestSchedulingErrorRecordInsufficientResource(t *testing.T) {
+	e := &SchedulingError{}
+	e.recordInsufficientResource(v1.ResourceList{v1.ResourceCPU: resource.MustParse("2")})
+	e.recordInsufficientResource(v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("1"),
+		v1.ResourceMemory: resource.MustParse("4Gi"),
+	})
+
+	if e.InsufficientResource != 2 {
+		t.Fatalf("InsufficientResource = %d, want 2", e.InsufficientResource)
+	}
+	// MissingResources should keep the largest shortfall seen per resource across calls, not the last one or a sum.
+	if got := e.MissingResources[v1.ResourceCPU]; got.Cmp(resource.MustParse("2")) != 0 {
+		t.Fatalf("MissingResources[cpu] = %s, want 2", got.String())
+	}
+	if got := e.MissingResources[v1.ResourceMemory]; got.Cmp(resource.MustParse("4Gi")) != 0 {
+		t.Fatalf("MissingResources[memory] = %s, want 4Gi", got.String())
+	}
+}
+
+func TestSchedulingErrorRecordNoOffering(t *testing.T) {
+	e := &SchedulingError{}
+	offerings := []cloudprovider.Offering{
+		{Zone: "us-east-1a", CapacityType: "spot", Price: 0.05},
+		{Zone: "us-east-1b", CapacityType: "on-demand", Price: 0.10},
+	}
+	e.recordNoOffering(offerings)
+
+	if e.NoOffering != 1 {
+		t.Fatalf("NoOffering = %d, want 1", e.NoOffering)
+	}
+	if len(e.UnavailableOfferings) != len(offerings) {
+		t.Fatalf("UnavailableOfferings = %v, want %v", e.UnavailableOfferings, offerings)
+	}
+}
+
+func TestSchedulingErrorError(t *testing.T) {
+	var nilErr *SchedulingError
+	if got := nilErr.Error(); got != "" {
+		t.Fatalf("nil SchedulingError.Error() = %q, want empty string", got)
+	}
+
+	e := &SchedulingError{Incompatible: 1, InsufficientResource: 2, NoOffering: 3}
+	if got := e.Error(); got == "" {
+		t.Fatalf("non-nil SchedulingError.Error() returned empty string")
+	}
+}
@@ -19,104 +19,112 @@ import (
 	"fmt"
 	"strings"
 	"sync/atomic"
+	"time"
 
 	"github.com/samber/lo"
+	"go.opentelemetry.io/otel"
 	v1 "k8s.io/api/core/v1"
 
 	"github.com/aws/karpenter-core/pkg/apis/v1alpha5"
+	"github.com/aws/karpenter-core/pkg/apis/v1beta1"
 	"github.com/aws/karpenter-core/pkg/cloudprovider"
 	"github.com/aws/karpenter-core/pkg/scheduling"
 	"github.com/aws/karpenter-core/pkg/utils/resources"
 )
 
-// Machine is a set of constraints, compatible pods, and possible instance types that could fulfill these constraints. This
+var tracer = otel.Tracer("karpenter-core/scheduling")
+
+// NodeClaim is a set of constraints, compatible pods, and possible instance types that could fulfill these constraints. This
 // will be turned into one or more actual node instances within the cluster after bin packing.
-type Machine struct {
-	MachineTemplate
+type NodeClaim struct {
+	NodeClaimTemplate
 
 	Pods          []*v1.Pod
 	topology      *Topology
 	hostPortUsage *scheduling.HostPortUsage
+
+	rankedInstanceTypeOptions []InstanceTypeScore
 }
 
+// Deprecated: Machine is an alias of NodeClaim retained for the v1alpha5 -> v1beta1 transition. It will be removed
+// once Provisioner/Machine are no longer served.
+type Machine = NodeClaim
+
 var nodeID int64
 
-func NewMachine(machineTemplate *MachineTemplate, topology *Topology, daemonResources v1.ResourceList, instanceTypes []*cloudprovider.InstanceType) *Machine {
+func NewNodeClaim(nodeClaimTemplate *NodeClaimTemplate, topology *Topology, daemonResources v1.ResourceList, instanceTypes []*cloudprovider.InstanceType) *NodeClaim {
 	// Copy the template, and add hostname
 	hostname := fmt.Sprintf("hostname-placeholder-%04d", atomic.AddInt64(&nodeID, 1))
 	topology.Register(v1.LabelHostname, hostname)
-	template := *machineTemplate
+	template := *nodeClaimTemplate
 	template.Requirements = scheduling.NewRequirements()
-	template.Requirements.Add(machineTemplate.Requirements.Values()...)
+	template.Requirements.Add(nodeClaimTemplate.Requirements.Values()...)
 	template.Requirements.Add(scheduling.NewRequirement(v1.LabelHostname, v1.NodeSelectorOpIn, hostname))
 	template.InstanceTypeOptions = instanceTypes
 	template.Requests = daemonResources
-
-	return &Machine{
-		MachineTemplate: template,
-		hostPortUsage:   scheduling.NewHostPortUsage(),
-		topology:        topology,
+	// Translate the v1alpha5 provisioner-name/capacity-type requirements onto their v1beta1 equivalents; see
+	// applyLegacyCompatibility for what this does and doesn't cover.
+	applyLegacyCompatibility(&template)
+
+	return &NodeClaim{
+		NodeClaimTemplate: template,
+		hostPortUsage:     scheduling.NewHostPortUsage(),
+		topology:          topology,
 	}
 }
 
-func (m *Machine) Add(ctx context.Context, pod *v1.Pod) error {
-	// Check Taints
-	if err := m.Taints.Tolerates(pod); err != nil {
-		return err
-	}
-
-	// exposed host ports on the node
-	if err := m.hostPortUsage.Validate(pod); err != nil {
-		return err
-	}
-
-	machineRequirements := scheduling.NewRequirements(m.Requirements.Values()...)
-	podRequirements := scheduling.NewPodRequirements(pod)
-
-	// Check Machine Affinity Requirements
-	if err := machineRequirements.Compatible(podRequirements); err != nil {
-		return fmt.Errorf("incompatible requirements, %w", err)
-	}
-	machineRequirements.Add(podRequirements.Values()...)
+// Deprecated: NewMachine is an alias of NewNodeClaim retained for the v1alpha5 -> v1beta1 transition. It will be
+// removed once Provisioner/Machine are no longer served.
+func NewMachine(machineTemplate *MachineTemplate, topology *Topology, daemonResources v1.ResourceList, instanceTypes []*cloudprovider.InstanceType) *Machine {
+	return NewNodeClaim(machineTemplate, topology, daemonResources, instanceTypes)
+}
 
-	// Check Topology Requirements
-	topologyRequirements, err := m.topology.AddRequirements(podRequirements, machineRequirements, pod)
-	if err != nil {
-		return err
-	}
-	if err = machineRequirements.Compatible(topologyRequirements); err != nil {
-		return err
-	}
-	machineRequirements.Add(topologyRequirements.Values()...)
-
-	// Check instance type combinations
-	requests := resources.Merge(m.Requests, resources.RequestsForPods(pod))
-	beforeOptsCount := len(m.InstanceTypeOptions)
-	instanceTypes, errors := filterInstanceTypesByRequirements(m.InstanceTypeOptions, machineRequirements, requests)
-	if len(instanceTypes) == 0 {
-		return fmt.Errorf("no instance type satisfied resources %s and requirements %s [had %d] (%v)",
-			resources.String(resources.RequestsForPods(pod)), machineRequirements, beforeOptsCount, errors)
+func (m *NodeClaim) Add(ctx context.Context, pod *v1.Pod) error {
+	ctx, span := tracer.Start(ctx, "NodeClaim.Add")
+	defer span.End()
+	start := time.Now()
+	defer func() { podFitDurationSeconds.Observe(time.Since(start).Seconds()) }()
+
+	// Run the registered predicate pipeline; the first predicate to reject the pod short-circuits the rest and
+	// its FitError identifies which check failed. On success, pc carries the requirements and instance types the
+	// predicates already computed, so we commit them directly instead of resolving topology and filtering
+	// instance types a second time.
+	predicatesStart := time.Now()
+	pc, fitErr := runPredicates(ctx, m, pod)
+	phaseDurationSeconds.WithLabelValues("predicates").Observe(time.Since(predicatesStart).Seconds())
+	if fitErr != nil {
+		predicateRejectionsTotal.WithLabelValues(fitErr.PredicateName).Inc()
+		recordFailedAttempt(pod, m, fitErr)
+		return fitErr
 	}
 
 	// Update node
 	m.Pods = append(m.Pods, pod)
-	m.InstanceTypeOptions = instanceTypes
-	m.Requests = requests
-	m.Requirements = machineRequirements
-	m.topology.Record(pod, machineRequirements)
+	m.InstanceTypeOptions = pc.InstanceTypes
+	m.Requests = pc.Requests
+	m.Requirements = pc.Requirements
+	m.topology.Record(pod, pc.Requirements)
 	m.hostPortUsage.Add(ctx, pod)
 	return nil
 }
 
 // FinalizeScheduling is called once all scheduling has completed and allows the node to perform any cleanup
 // necessary before its requirements are used for instance launching
-func (m *Machine) FinalizeScheduling() {
+func (m *NodeClaim) FinalizeScheduling() {
 	// We need nodes to have hostnames for topology purposes, but we don't want to pass that node name on to consumers
 	// of the node as it will be displayed in error messages
 	delete(m.Requirements, v1.LabelHostname)
+	m.rankedInstanceTypeOptions = scoreInstanceTypes(m.InstanceTypeOptions, m)
+}
+
+// RankedInstanceTypeOptions returns this NodeClaim's surviving instance types ordered highest-scoring first, as
+// computed by scoreInstanceTypes during FinalizeScheduling. Bin-packing and launch code should prefer earlier
+// entries, e.g. to implement a "cheapest that fits" or "densest packing" launch policy.
+func (m *NodeClaim) RankedInstanceTypeOptions() []InstanceTypeScore {
+	return m.rankedInstanceTypeOptions
 }
 
-func (m *Machine) String() string {
+func (m *NodeClaim) String() string {
 	return fmt.Sprintf("machine with %d pods requesting %s from types %s", len(m.Pods), resources.String(m.Requests),
 		InstanceTypeList(m.InstanceTypeOptions))
 }
@@ -136,46 +144,93 @@ func InstanceTypeList(instanceTypeOptions []*cloudprovider.InstanceType) string
 	return itSb.String()
 }
 
-func filterInstanceTypesByRequirements(instanceTypes []*cloudprovider.InstanceType, requirements scheduling.Requirements, requests v1.ResourceList) ([]*cloudprovider.InstanceType, []string) {
+func filterInstanceTypesByRequirements(ctx context.Context, instanceTypes []*cloudprovider.InstanceType, requirements scheduling.Requirements, requests v1.ResourceList) ([]*cloudprovider.InstanceType, *SchedulingError) {
+	_, span := tracer.Start(ctx, "filterInstanceTypesByRequirements")
+	defer span.End()
+
+	start := time.Now()
+	defer func() {
+		phaseDurationSeconds.WithLabelValues("filter-instance-types").Observe(time.Since(start).Seconds())
+	}()
 
-	var errors []string
-	incompatCount := 0
-	fitsCount := 0
-	hasOfferingCount := 0
+	schedErr := &SchedulingError{}
 
 	results := lo.Filter(instanceTypes, func(instanceType *cloudprovider.InstanceType, _ int) bool {
 		if !compatible(instanceType, requirements) {
-			incompatCount++
-			//errors = append(errors, fmt.Sprintf("%s incompatible with %v vs %v", instanceType.Name, instanceType.Requirements, requirements))
+			schedErr.recordIncompatible(conflictingKeys(instanceType, requirements)...)
 		}
 		if !fits(instanceType, requests) {
-			fitsCount++
-			//errors = append(errors, fmt.Sprintf("%s doesn't fit with %v vs %v", instanceType.Name, instanceType.Allocatable(), requests))
+			schedErr.recordInsufficientResource(insufficientResources(requests, instanceType.Allocatable()))
 		}
 		if !hasOffering(instanceType, requirements) {
-			hasOfferingCount++
-			//errors = append(errors, fmt.Sprintf("%s doesn't have offering with %v vs %v", instanceType.Name, requirements, instanceType.Offerings))
+			schedErr.recordNoOffering(instanceType.Offerings.Available())
 		}
 		return compatible(instanceType, requirements) && fits(instanceType, requests) && hasOffering(instanceType, requirements)
 	})
-	errors = append(errors, fmt.Sprintf("%d incompatibile, %d won't fit, %d no offerings", incompatCount, fitsCount, hasOfferingCount))
-	return results, errors
+	return results, schedErr
 }
 
 func compatible(instanceType *cloudprovider.InstanceType, requirements scheduling.Requirements) bool {
 	return instanceType.Requirements.Intersects(requirements) == nil
 }
 
+// conflictingKeys returns the requirement keys shared by instanceType and requirements whose value sets don't
+// overlap, i.e. the keys that actually caused compatible's Intersects check to fail. Only these are reported on
+// SchedulingError.IncompatibleKeys, not every key in the combined node/pod/topology requirement set.
+func conflictingKeys(instanceType *cloudprovider.InstanceType, requirements scheduling.Requirements) []string {
+	var keys []string
+	for _, key := range lo.Keys(instanceType.Requirements) {
+		if !requirements.Has(key) {
+			continue
+		}
+		itValues := instanceType.Requirements.Get(key)
+		reqValues := requirements.Get(key)
+		if !lo.SomeBy(itValues.Values(), func(v string) bool { return reqValues.Has(v) }) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
 func fits(instanceType *cloudprovider.InstanceType, requests v1.ResourceList) bool {
 	return resources.Fits(requests, instanceType.Allocatable())
 }
 
+// insufficientResources returns, for each resource requests exceeds allocatable on, the amount by which it's
+// short. Resources requests doesn't exceed allocatable on are omitted rather than reported as a negative surplus.
+func insufficientResources(requests, allocatable v1.ResourceList) v1.ResourceList {
+	missing := v1.ResourceList{}
+	for name, req := range requests {
+		alloc := allocatable[name]
+		if req.Cmp(alloc) > 0 {
+			shortfall := req.DeepCopy()
+			shortfall.Sub(alloc)
+			missing[name] = shortfall
+		}
+	}
+	return missing
+}
+
 func hasOffering(instanceType *cloudprovider.InstanceType, requirements scheduling.Requirements) bool {
 	for _, offering := range instanceType.Offerings.Available() {
 		if (!requirements.Has(v1.LabelTopologyZone) || requirements.Get(v1.LabelTopologyZone).Has(offering.Zone)) &&
-			(!requirements.Has(v1alpha5.LabelCapacityType) || requirements.Get(v1alpha5.LabelCapacityType).Has(offering.CapacityType)) {
+			(!requiresCapacityType(requirements) || capacityTypeRequirement(requirements).Has(offering.CapacityType)) {
 			return true
 		}
 	}
 	return false
 }
+
+// requiresCapacityType and capacityTypeRequirement check both the v1beta1 and legacy v1alpha5 capacity-type label
+// keys, so hasOffering stays correct for requirements built directly rather than through NewNodeClaim's
+// applyLegacyCompatibility.
+func requiresCapacityType(requirements scheduling.Requirements) bool {
+	return requirements.Has(v1beta1.LabelCapacityType) || requirements.Has(v1alpha5.LabelCapacityType)
+}
+
+func capacityTypeRequirement(requirements scheduling.Requirements) scheduling.Requirement {
+	if requirements.Has(v1beta1.LabelCapacityType) {
+		return requirements.Get(v1beta1.LabelCapacityType)
+	}
+	return requirements.Get(v1alpha5.LabelCapacityType)
+}
@@ -0,0 +1,43 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestInsufficientResources(t *testing.T) {
+	requests := v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("4"),
+		v1.ResourceMemory: resource.MustParse("4Gi"),
+	}
+	allocatable := v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse("2"),
+		v1.ResourceMemory: resource.MustParse("8Gi"),
+	}
+
+	missing := insufficientResources(requests, allocatable)
+
+	// CPU is short by 2; memory fits and should not be reported at all, let alone as a negative surplus.
+	if got := missing[v1.ResourceCPU]; got.Cmp(resource.MustParse("2")) != 0 {
+		t.Fatalf("missing[cpu] = %s, want 2", got.String())
+	}
+	if _, ok := missing[v1.ResourceMemory]; ok {
+		t.Fatalf("missing[memory] should be omitted, got %s", missing[v1.ResourceMemory].String())
+	}
+}
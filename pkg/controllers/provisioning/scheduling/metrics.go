@@ -0,0 +1,75 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	metricsSubsystem = "scheduling"
+)
+
+var (
+	// instanceTypeCount tracks how many candidate instance types survive each filter stage, labeled by stage.
+	instanceTypeCount = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "karpenter",
+			Subsystem: metricsSubsystem,
+			Name:      "instance_type_count",
+			Help:      "Number of candidate instance types remaining at a given scheduling stage.",
+			Buckets:   []float64{1, 5, 10, 25, 50, 100, 250, 500},
+		},
+		[]string{"stage"},
+	)
+	// predicateRejectionsTotal counts how many times each predicate rejected a pod, attributing scheduling
+	// failures to a specific check instead of a free-form error string.
+	predicateRejectionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "karpenter",
+			Subsystem: metricsSubsystem,
+			Name:      "predicate_rejections_total",
+			Help:      "Number of times a scheduling predicate rejected a pod, labeled by predicate name.",
+		},
+		[]string{"predicate"},
+	)
+	// phaseDurationSeconds times each scheduling phase (predicates, instance type filtering).
+	phaseDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "karpenter",
+			Subsystem: metricsSubsystem,
+			Name:      "phase_duration_seconds",
+			Help:      "Wall-time of a scheduling phase.",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"phase"},
+	)
+	// podFitDurationSeconds times NodeClaim.Add end-to-end, i.e. how long it took to decide whether one pod fits
+	// one candidate NodeClaim.
+	podFitDurationSeconds = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "karpenter",
+			Subsystem: metricsSubsystem,
+			Name:      "pod_fit_duration_seconds",
+			Help:      "Time to evaluate whether a single pod fits a single NodeClaim.",
+			Buckets:   prometheus.DefBuckets,
+		},
+	)
+)
+
+func init() {
+	crmetrics.Registry.MustRegister(instanceTypeCount, predicateRejectionsTotal, phaseDurationSeconds, podFitDurationSeconds)
+}
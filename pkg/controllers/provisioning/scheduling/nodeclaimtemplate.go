@@ -0,0 +1,49 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/aws/karpenter-core/pkg/apis/v1alpha5"
+	"github.com/aws/karpenter-core/pkg/apis/v1beta1"
+	corescheduling "github.com/aws/karpenter-core/pkg/scheduling"
+)
+
+// NodeClaimTemplate is the v1beta1 NodePool-aligned replacement for MachineTemplate.
+//
+// Deprecated: MachineTemplate is an alias of NodeClaimTemplate retained for the v1alpha5 -> v1beta1 transition.
+type MachineTemplate = NodeClaimTemplate
+
+// applyLegacyCompatibility duplicates the v1alpha5 provisioner-name and capacity-type requirements a
+// NodeClaimTemplate carries onto their v1beta1 equivalents, so Provisioner/Machine-sourced candidates keep matching
+// checks that look only at the v1beta1 label keys. It is not a general conversion: other v1alpha5-only
+// fields (taints, startup taints, limits, any other requirement key) have no v1beta1 equivalent here and are left
+// untranslated. It is called unconditionally by NewNodeClaim and is a no-op for templates that are already
+// v1beta1-native.
+//
+// This is an in-process, scheduling-simulation-only shim, not a CRD conversion webhook: it does not touch the
+// Provisioner/Machine or NodePool/NodeClaim objects stored in the API server, so anything reading those objects
+// directly (kubectl, other controllers, status) still sees v1alpha5-only resources as v1alpha5. This change does
+// not add a conversion webhook for the stored objects; that's a separate, not-yet-scheduled piece of work and
+// reviewers should treat it as outstanding, not deferred to a tracked item.
+func applyLegacyCompatibility(nct *NodeClaimTemplate) {
+	if nct.Requirements.Has(v1alpha5.LabelProvisionerName) {
+		nct.Requirements.Add(corescheduling.NewRequirement(v1beta1.LabelNodePool, v1.NodeSelectorOpIn, nct.Requirements.Get(v1alpha5.LabelProvisionerName).Values()...))
+	}
+	if nct.Requirements.Has(v1alpha5.LabelCapacityType) {
+		nct.Requirements.Add(corescheduling.NewRequirement(v1beta1.LabelCapacityType, v1.NodeSelectorOpIn, nct.Requirements.Get(v1alpha5.LabelCapacityType).Values()...))
+	}
+}
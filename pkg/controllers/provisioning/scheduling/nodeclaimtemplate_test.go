@@ -0,0 +1,74 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/aws/karpenter-core/pkg/apis/v1alpha5"
+	"github.com/aws/karpenter-core/pkg/apis/v1beta1"
+	corescheduling "github.com/aws/karpenter-core/pkg/scheduling"
+)
+
+func TestApplyLegacyCompatibilityCopiesProvisionerName(t *testing.T) {
+	nct := &NodeClaimTemplate{Requirements: corescheduling.NewRequirements(
+		corescheduling.NewRequirement(v1alpha5.LabelProvisionerName, v1.NodeSelectorOpIn, "default"),
+	)}
+
+	applyLegacyCompatibility(nct)
+
+	if !nct.Requirements.Has(v1beta1.LabelNodePool) {
+		t.Fatalf("expected %s to be copied onto %s, got %v", v1alpha5.LabelProvisionerName, v1beta1.LabelNodePool, nct.Requirements)
+	}
+	if got := nct.Requirements.Get(v1beta1.LabelNodePool).Values(); len(got) != 1 || got[0] != "default" {
+		t.Fatalf("%s values = %v, want [default]", v1beta1.LabelNodePool, got)
+	}
+}
+
+func TestApplyLegacyCompatibilityCopiesCapacityType(t *testing.T) {
+	nct := &NodeClaimTemplate{Requirements: corescheduling.NewRequirements(
+		corescheduling.NewRequirement(v1alpha5.LabelCapacityType, v1.NodeSelectorOpIn, "spot"),
+	)}
+
+	applyLegacyCompatibility(nct)
+
+	if !nct.Requirements.Has(v1beta1.LabelCapacityType) {
+		t.Fatalf("expected %s to be copied onto %s, got %v", v1alpha5.LabelCapacityType, v1beta1.LabelCapacityType, nct.Requirements)
+	}
+	if got := nct.Requirements.Get(v1beta1.LabelCapacityType).Values(); len(got) != 1 || got[0] != "spot" {
+		t.Fatalf("%s values = %v, want [spot]", v1beta1.LabelCapacityType, got)
+	}
+}
+
+// TestApplyLegacyCompatibilityNoopForV1Beta1Native guards against a NodeClaimTemplate that only carries the
+// v1beta1 labels (no v1alpha5.Provisioner/Machine in the picture) getting mutated at all.
+func TestApplyLegacyCompatibilityNoopForV1Beta1Native(t *testing.T) {
+	nct := &NodeClaimTemplate{Requirements: corescheduling.NewRequirements(
+		corescheduling.NewRequirement(v1beta1.LabelNodePool, v1.NodeSelectorOpIn, "default"),
+		corescheduling.NewRequirement(v1beta1.LabelCapacityType, v1.NodeSelectorOpIn, "on-demand"),
+	)}
+	want := corescheduling.NewRequirements(nct.Requirements.Values()...)
+
+	applyLegacyCompatibility(nct)
+
+	if nct.Requirements.Has(v1alpha5.LabelProvisionerName) || nct.Requirements.Has(v1alpha5.LabelCapacityType) {
+		t.Fatalf("v1beta1-native template should not gain v1alpha5 requirements, got %v", nct.Requirements)
+	}
+	if len(nct.Requirements.Values()) != len(want.Values()) {
+		t.Fatalf("v1beta1-native template should be left untouched, got %v, want %v", nct.Requirements, want)
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/aws/karpenter-core/pkg/cloudprovider"
+	"github.com/aws/karpenter-core/pkg/scheduling"
+	"github.com/aws/karpenter-core/pkg/utils/resources"
+)
+
+// PredicateContext accumulates the NodeClaim state a pod-fit check computes as it runs, so later predicates (and
+// the caller on success) reuse it instead of recomputing topology resolution and instance-type filtering.
+type PredicateContext struct {
+	Requirements  scheduling.Requirements
+	Requests      v1.ResourceList
+	InstanceTypes []*cloudprovider.InstanceType
+}
+
+// Predicate determines whether a pod can be added to a NodeClaim. Predicates run in registration order and the
+// first one to fail short-circuits the remainder.
+type Predicate interface {
+	// Name identifies the predicate for FitError attribution and metrics.
+	Name() string
+	// Filter reports whether pod can be added to n given the requirements/instance types accumulated in pc so
+	// far, returning a non-nil FitError describing why not.
+	Filter(ctx context.Context, n *NodeClaim, pod *v1.Pod, pc *PredicateContext) *FitError
+}
+
+// FitError is returned by a Predicate when a pod does not fit a NodeClaim. It carries enough structure for callers
+// to attribute the rejection to a specific predicate rather than parsing an error string.
+type FitError struct {
+	PredicateName string
+	Reason        string
+	// SchedulingError carries the structured breakdown when PredicateName is "InstanceTypeFit"; nil otherwise.
+	SchedulingError *SchedulingError
+}
+
+func (e *FitError) Error() string {
+	return fmt.Sprintf("%s: %s", e.PredicateName, e.Reason)
+}
+
+// predicateRegistryMu guards predicateRegistry, which RegisterPredicate mutates at startup.
+var (
+	predicateRegistryMu sync.Mutex
+	predicateRegistry   = []Predicate{
+		taintsPredicate{},
+		hostPortPredicate{},
+		requirementsPredicate{},
+		topologyPredicate{},
+		instanceTypePredicate{},
+	}
+)
+
+// RegisterPredicate appends p to the set of predicates run by NodeClaim.Add, after the core predicates (taints,
+// host ports, requirements, topology, instance type availability).
+func RegisterPredicate(p Predicate) {
+	predicateRegistryMu.Lock()
+	defer predicateRegistryMu.Unlock()
+	predicateRegistry = append(predicateRegistry, p)
+}
+
+// runPredicates runs the registered predicates in order against n and pod, short-circuiting on the first
+// rejection. On success it returns the accumulated PredicateContext so the caller can commit it to n directly.
+func runPredicates(ctx context.Context, n *NodeClaim, pod *v1.Pod) (*PredicateContext, *FitError) {
+	predicateRegistryMu.Lock()
+	predicates := predicateRegistry
+	predicateRegistryMu.Unlock()
+
+	pc := &PredicateContext{
+		Requirements:  scheduling.NewRequirements(n.Requirements.Values()...),
+		Requests:      resources.Merge(n.Requests, resources.RequestsForPods(pod)),
+		InstanceTypes: n.InstanceTypeOptions,
+	}
+	for _, p := range predicates {
+		if fitErr := p.Filter(ctx, n, pod, pc); fitErr != nil {
+			return nil, fitErr
+		}
+	}
+	return pc, nil
+}
+
+type taintsPredicate struct{}
+
+func (taintsPredicate) Name() string { return "Taints" }
+func (taintsPredicate) Filter(_ context.Context, n *NodeClaim, pod *v1.Pod, _ *PredicateContext) *FitError {
+	if err := n.Taints.Tolerates(pod); err != nil {
+		return &FitError{PredicateName: "Taints", Reason: err.Error()}
+	}
+	return nil
+}
+
+type hostPortPredicate struct{}
+
+func (hostPortPredicate) Name() string { return "HostPorts" }
+func (hostPortPredicate) Filter(_ context.Context, n *NodeClaim, pod *v1.Pod, _ *PredicateContext) *FitError {
+	if err := n.hostPortUsage.Validate(pod); err != nil {
+		return &FitError{PredicateName: "HostPorts", Reason: err.Error()}
+	}
+	return nil
+}
+
+// requirementsPredicate checks pod's requirements against pc.Requirements and folds them in on success.
+type requirementsPredicate struct{}
+
+func (requirementsPredicate) Name() string { return "Requirements" }
+func (requirementsPredicate) Filter(_ context.Context, n *NodeClaim, pod *v1.Pod, pc *PredicateContext) *FitError {
+	podRequirements := scheduling.NewPodRequirements(pod)
+	if err := pc.Requirements.Compatible(podRequirements); err != nil {
+		return &FitError{PredicateName: "Requirements", Reason: err.Error()}
+	}
+	pc.Requirements.Add(podRequirements.Values()...)
+	return nil
+}
+
+// topologyPredicate resolves topology spread/affinity requirements against pc.Requirements and folds them in.
+type topologyPredicate struct{}
+
+func (topologyPredicate) Name() string { return "Topology" }
+func (topologyPredicate) Filter(_ context.Context, n *NodeClaim, pod *v1.Pod, pc *PredicateContext) *FitError {
+	podRequirements := scheduling.NewPodRequirements(pod)
+	topologyRequirements, err := n.topology.AddRequirements(podRequirements, pc.Requirements, pod)
+	if err != nil {
+		return &FitError{PredicateName: "Topology", Reason: err.Error()}
+	}
+	if err = pc.Requirements.Compatible(topologyRequirements); err != nil {
+		return &FitError{PredicateName: "Topology", Reason: err.Error()}
+	}
+	pc.Requirements.Add(topologyRequirements.Values()...)
+	return nil
+}
+
+// instanceTypePredicate filters pc.InstanceTypes down to the ones compatible with pc.Requirements/pc.Requests.
+type instanceTypePredicate struct{}
+
+func (instanceTypePredicate) Name() string { return "InstanceTypeFit" }
+func (instanceTypePredicate) Filter(ctx context.Context, n *NodeClaim, pod *v1.Pod, pc *PredicateContext) *FitError {
+	instanceTypeCount.WithLabelValues("before-filter").Observe(float64(len(pc.InstanceTypes)))
+	instanceTypes, schedErr := filterInstanceTypesByRequirements(ctx, pc.InstanceTypes, pc.Requirements, pc.Requests)
+	instanceTypeCount.WithLabelValues("after-filter").Observe(float64(len(instanceTypes)))
+	if len(instanceTypes) == 0 {
+		return &FitError{
+			PredicateName: "InstanceTypeFit",
+			Reason: fmt.Sprintf("no instance type satisfied resources %s and requirements %s [had %d] (%s)",
+				resources.String(resources.RequestsForPods(pod)), pc.Requirements, len(pc.InstanceTypes), schedErr),
+			SchedulingError: schedErr,
+		}
+	}
+	pc.InstanceTypes = instanceTypes
+	return nil
+}
@@ -0,0 +1,149 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"context"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+
+	corescheduling "github.com/aws/karpenter-core/pkg/scheduling"
+)
+
+// fakePredicate records its own name onto calls when run, and rejects if reject is set. It lets the pipeline tests
+// below exercise ordering/short-circuit behavior without standing up the real built-ins' taint/topology/host-port
+// dependencies.
+type fakePredicate struct {
+	name   string
+	reject bool
+	calls  *[]string
+}
+
+func (f fakePredicate) Name() string { return f.name }
+
+func (f fakePredicate) Filter(_ context.Context, _ *NodeClaim, _ *v1.Pod, _ *PredicateContext) *FitError {
+	*f.calls = append(*f.calls, f.name)
+	if f.reject {
+		return &FitError{PredicateName: f.name, Reason: "rejected by " + f.name}
+	}
+	return nil
+}
+
+// withPredicates swaps the package-level predicateRegistry for the duration of a test, restoring it on cleanup.
+func withPredicates(t *testing.T, predicates []Predicate) {
+	t.Helper()
+	predicateRegistryMu.Lock()
+	original := predicateRegistry
+	predicateRegistry = predicates
+	predicateRegistryMu.Unlock()
+	t.Cleanup(func() {
+		predicateRegistryMu.Lock()
+		predicateRegistry = original
+		predicateRegistryMu.Unlock()
+	})
+}
+
+func testNodeClaim() *NodeClaim {
+	return &NodeClaim{
+		NodeClaimTemplate: NodeClaimTemplate{
+			Requirements: corescheduling.NewRequirements(),
+			Requests:     v1.ResourceList{},
+		},
+	}
+}
+
+func TestRunPredicatesOrderAndShortCircuit(t *testing.T) {
+	var calls []string
+	withPredicates(t, []Predicate{
+		fakePredicate{name: "first", calls: &calls},
+		fakePredicate{name: "second", reject: true, calls: &calls},
+		fakePredicate{name: "third", calls: &calls},
+	})
+
+	pc, fitErr := runPredicates(context.Background(), testNodeClaim(), &v1.Pod{})
+	if fitErr == nil {
+		t.Fatal("expected a FitError from the rejecting predicate, got nil")
+	}
+	if pc != nil {
+		t.Fatalf("expected a nil PredicateContext on rejection, got %+v", pc)
+	}
+	if got, want := calls, []string{"first", "second"}; !equalStrings(got, want) {
+		t.Fatalf("predicates called = %v, want %v (third should be short-circuited)", got, want)
+	}
+}
+
+func TestRunPredicatesAttributesFitErrorToRejectingPredicate(t *testing.T) {
+	var calls []string
+	withPredicates(t, []Predicate{
+		fakePredicate{name: "first", calls: &calls},
+		fakePredicate{name: "second", reject: true, calls: &calls},
+	})
+
+	_, fitErr := runPredicates(context.Background(), testNodeClaim(), &v1.Pod{})
+	if fitErr == nil {
+		t.Fatal("expected a FitError, got nil")
+	}
+	if fitErr.PredicateName != "second" {
+		t.Fatalf("FitError.PredicateName = %q, want %q", fitErr.PredicateName, "second")
+	}
+}
+
+func TestRunPredicatesAllPass(t *testing.T) {
+	var calls []string
+	withPredicates(t, []Predicate{
+		fakePredicate{name: "first", calls: &calls},
+		fakePredicate{name: "second", calls: &calls},
+	})
+
+	pc, fitErr := runPredicates(context.Background(), testNodeClaim(), &v1.Pod{})
+	if fitErr != nil {
+		t.Fatalf("expected no FitError, got %v", fitErr)
+	}
+	if pc == nil {
+		t.Fatal("expected a non-nil PredicateContext when all predicates pass")
+	}
+	if got, want := calls, []string{"first", "second"}; !equalStrings(got, want) {
+		t.Fatalf("predicates called = %v, want %v", got, want)
+	}
+}
+
+func TestRegisterPredicateAppendsAndRuns(t *testing.T) {
+	var calls []string
+	withPredicates(t, []Predicate{
+		fakePredicate{name: "first", calls: &calls},
+	})
+
+	RegisterPredicate(fakePredicate{name: "custom", calls: &calls})
+
+	if _, fitErr := runPredicates(context.Background(), testNodeClaim(), &v1.Pod{}); fitErr != nil {
+		t.Fatalf("expected no FitError, got %v", fitErr)
+	}
+	if got, want := calls, []string{"first", "custom"}; !equalStrings(got, want) {
+		t.Fatalf("predicates called = %v, want %v (RegisterPredicate should append after existing predicates and run)", got, want)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
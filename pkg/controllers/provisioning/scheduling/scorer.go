@@ -0,0 +1,186 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"math"
+	"sort"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/aws/karpenter-core/pkg/apis/v1beta1"
+	"github.com/aws/karpenter-core/pkg/cloudprovider"
+)
+
+// maxScore is the normalized upper bound each InstanceTypeScorer must scale its raw score into, mirroring
+// kube-scheduler's 0-100 priority function range.
+const maxScore int64 = 100
+
+// InstanceTypeScorer ranks a candidate instance type for a NodeClaim. Score must return a value in [0, maxScore];
+// the pipeline does the weighting and summation.
+type InstanceTypeScorer interface {
+	// Name identifies the scorer for debugging and metrics.
+	Name() string
+	// Score rates instanceType's fitness for n in [0, maxScore]. Higher is more preferred.
+	Score(instanceType *cloudprovider.InstanceType, n *NodeClaim) int64
+	// Weight scales this scorer's contribution to the summed score.
+	Weight() int64
+}
+
+var (
+	scorerRegistryMu sync.Mutex
+	scorerRegistry   = []InstanceTypeScorer{
+		lowestPriceScorer{},
+		packingEfficiencyScorer{},
+		spotPreferenceScorer{},
+		leastWastedCapacityScorer{},
+	}
+)
+
+// RegisterScorer adds an InstanceTypeScorer to the set run by scoreInstanceTypes. Cloud providers use this to rank
+// instance types on criteria core doesn't know about, e.g. burstable-credit awareness.
+func RegisterScorer(s InstanceTypeScorer) {
+	scorerRegistryMu.Lock()
+	defer scorerRegistryMu.Unlock()
+	scorerRegistry = append(scorerRegistry, s)
+}
+
+// InstanceTypeScore is an instance type paired with its summed, weighted score from scoreInstanceTypes.
+type InstanceTypeScore struct {
+	*cloudprovider.InstanceType
+	Score int64
+}
+
+// scoreInstanceTypes ranks instanceTypes for n, highest score first, by summing each registered scorer's weighted
+// contribution. It only ranks; filtering for fit happens in filterInstanceTypesByRequirements.
+func scoreInstanceTypes(instanceTypes []*cloudprovider.InstanceType, n *NodeClaim) []InstanceTypeScore {
+	scorerRegistryMu.Lock()
+	scorers := scorerRegistry
+	scorerRegistryMu.Unlock()
+
+	scored := make([]InstanceTypeScore, 0, len(instanceTypes))
+	for _, it := range instanceTypes {
+		var total int64
+		for _, scorer := range scorers {
+			total += scorer.Weight() * clampScore(scorer.Score(it, n))
+		}
+		scored = append(scored, InstanceTypeScore{InstanceType: it, Score: total})
+	}
+	sort.SliceStable(scored, func(i, j int) bool { return scored[i].Score > scored[j].Score })
+	return scored
+}
+
+func clampScore(score int64) int64 {
+	switch {
+	case score < 0:
+		return 0
+	case score > maxScore:
+		return maxScore
+	default:
+		return score
+	}
+}
+
+// resourceRatio returns how full allocatable would be if requests were scheduled onto it, in [0, 1], blending CPU
+// and memory by averaging their per-resource requested/allocatable fractions.
+func resourceRatio(requests, allocatable v1.ResourceList) float64 {
+	var sum float64
+	var count int
+	for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		alloc, ok := allocatable[name]
+		if !ok || alloc.IsZero() {
+			continue
+		}
+		req := requests[name]
+		sum += math.Min(req.AsApproximateFloat64()/alloc.AsApproximateFloat64(), 1)
+		count++
+	}
+	if count == 0 {
+		return 0
+	}
+	return sum / float64(count)
+}
+
+// maxResourceWaste returns, in [0, 1], the largest per-resource fraction of allocatable left unused once requests
+// are scheduled onto it, considering CPU and memory independently rather than blending them into one ratio.
+func maxResourceWaste(requests, allocatable v1.ResourceList) float64 {
+	var maxWaste float64
+	for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+		alloc, ok := allocatable[name]
+		if !ok || alloc.IsZero() {
+			continue
+		}
+		req := requests[name]
+		waste := 1 - math.Min(req.AsApproximateFloat64()/alloc.AsApproximateFloat64(), 1)
+		if waste > maxWaste {
+			maxWaste = waste
+		}
+	}
+	return maxWaste
+}
+
+// lowestPriceScorer prefers instance types with the cheapest available offering.
+type lowestPriceScorer struct{}
+
+func (lowestPriceScorer) Name() string  { return "LowestPrice" }
+func (lowestPriceScorer) Weight() int64 { return 1 }
+func (lowestPriceScorer) Score(it *cloudprovider.InstanceType, _ *NodeClaim) int64 {
+	lowest := math.MaxFloat64
+	for _, o := range it.Offerings.Available() {
+		if o.Price < lowest {
+			lowest = o.Price
+		}
+	}
+	if lowest == math.MaxFloat64 || lowest <= 0 {
+		return 0
+	}
+	// cheaper offerings score closer to maxScore
+	return clampScore(int64(float64(maxScore) / (1 + lowest)))
+}
+
+// packingEfficiencyScorer prefers instance types whose allocatable resources are closest to what the NodeClaim
+// has requested so far.
+type packingEfficiencyScorer struct{}
+
+func (packingEfficiencyScorer) Name() string  { return "PackingEfficiency" }
+func (packingEfficiencyScorer) Weight() int64 { return 1 }
+func (packingEfficiencyScorer) Score(it *cloudprovider.InstanceType, n *NodeClaim) int64 {
+	return clampScore(int64(resourceRatio(n.Requests, it.Allocatable()) * float64(maxScore)))
+}
+
+// spotPreferenceScorer prefers instance types that offer spot capacity.
+type spotPreferenceScorer struct{}
+
+func (spotPreferenceScorer) Name() string  { return "SpotPreference" }
+func (spotPreferenceScorer) Weight() int64 { return 1 }
+func (spotPreferenceScorer) Score(it *cloudprovider.InstanceType, _ *NodeClaim) int64 {
+	for _, o := range it.Offerings.Available() {
+		if o.CapacityType == v1beta1.CapacityTypeSpot {
+			return maxScore
+		}
+	}
+	return 0
+}
+
+// leastWastedCapacityScorer prefers instance types with the least slack in their single most oversized resource
+// dimension, unlike packingEfficiencyScorer's blended CPU/memory ratio.
+type leastWastedCapacityScorer struct{}
+
+func (leastWastedCapacityScorer) Name() string  { return "LeastWastedCapacity" }
+func (leastWastedCapacityScorer) Weight() int64 { return 1 }
+func (leastWastedCapacityScorer) Score(it *cloudprovider.InstanceType, n *NodeClaim) int64 {
+	return clampScore(int64((1 - maxResourceWaste(n.Requests, it.Allocatable())) * float64(maxScore)))
+}
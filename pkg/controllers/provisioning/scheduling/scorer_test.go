@@ -0,0 +1,91 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scheduling
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func resourceList(cpu, memory string) v1.ResourceList {
+	return v1.ResourceList{
+		v1.ResourceCPU:    resource.MustParse(cpu),
+		v1.ResourceMemory: resource.MustParse(memory),
+	}
+}
+
+func TestResourceRatio(t *testing.T) {
+	cases := []struct {
+		name        string
+		requests    v1.ResourceList
+		allocatable v1.ResourceList
+		want        float64
+	}{
+		{"half of both", resourceList("1", "1Gi"), resourceList("2", "2Gi"), 0.5},
+		{"fully used", resourceList("2", "2Gi"), resourceList("2", "2Gi"), 1},
+		{"nothing requested", resourceList("0", "0"), resourceList("2", "2Gi"), 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := resourceRatio(c.requests, c.allocatable); got != c.want {
+				t.Errorf("resourceRatio() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
+// TestLeastWastedCapacityDiffersFromPackingEfficiency guards against the two scorers collapsing to the same
+// formula: an instance type that's balanced on average (packing efficiency blends CPU and memory) but heavily
+// oversized in a single dimension should score worse on least-wasted-capacity than one that's evenly oversized.
+func TestLeastWastedCapacityDiffersFromPackingEfficiency(t *testing.T) {
+	requests := resourceList("2", "2Gi")
+
+	// CPU-tight, memory is way oversized: blended ratio looks fine, but memory is badly wasted.
+	imbalanced := resourceList("2", "16Gi")
+	// Both CPU and memory equally oversized (2x): blended ratio 0.5, just below imbalanced's 0.5625.
+	balanced := resourceList("4", "4Gi")
+
+	packingImbalanced := resourceRatio(requests, imbalanced)
+	packingBalanced := resourceRatio(requests, balanced)
+	if packingImbalanced <= packingBalanced {
+		t.Fatalf("expected imbalanced type to look better packed on the blended ratio, got %v <= %v", packingImbalanced, packingBalanced)
+	}
+
+	wasteImbalanced := maxResourceWaste(requests, imbalanced)
+	wasteBalanced := maxResourceWaste(requests, balanced)
+	if wasteImbalanced <= wasteBalanced {
+		t.Fatalf("expected imbalanced type to be flagged as more wasted once resources are considered independently, got %v <= %v", wasteImbalanced, wasteBalanced)
+	}
+}
+
+func TestClampScore(t *testing.T) {
+	cases := []struct {
+		in   int64
+		want int64
+	}{
+		{-10, 0},
+		{0, 0},
+		{50, 50},
+		{maxScore, maxScore},
+		{maxScore + 1, maxScore},
+	}
+	for _, c := range cases {
+		if got := clampScore(c.in); got != c.want {
+			t.Errorf("clampScore(%d) = %d, want %d", c.in, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/aws/karpenter-core/pkg/controllers/provisioning/scheduling"
+)
+
+const debugPath = "/debug/scheduling/failed-attempts"
+
+// registeredMuxesMu guards registeredMuxes, which RegisterDebugEndpoints consults to stay idempotent per mux.
+var (
+	registeredMuxesMu sync.Mutex
+	registeredMuxes   = map[*http.ServeMux]bool{}
+)
+
+// RegisterDebugEndpoints wires scheduling's failed-attempts dump onto mux alongside the other debug/pprof
+// endpoints. Nothing registers this automatically, including on http.DefaultServeMux: callers must call this
+// explicitly for whichever mux they serve debug endpoints on. Calling it more than once for the same mux is a
+// no-op, so operators wiring it from multiple call sites don't hit ServeMux's panic-on-duplicate-pattern behavior.
+func RegisterDebugEndpoints(mux *http.ServeMux) {
+	registeredMuxesMu.Lock()
+	defer registeredMuxesMu.Unlock()
+	if registeredMuxes[mux] {
+		return
+	}
+	mux.HandleFunc(debugPath, scheduling.DebugHandler)
+	registeredMuxes[mux] = true
+}
@@ -0,0 +1,57 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operator
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRegisterDebugEndpointsServesJSON(t *testing.T) {
+	mux := http.NewServeMux()
+	RegisterDebugEndpoints(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, debugPath, nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var body []interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("response body is not valid JSON: %v (%s)", err, rec.Body.String())
+	}
+}
+
+// TestRegisterDebugEndpointsDoubleRegistrationIsNoop guards against the panic ServeMux raises when the same
+// pattern is registered on it twice.
+func TestRegisterDebugEndpointsDoubleRegistrationIsNoop(t *testing.T) {
+	mux := http.NewServeMux()
+	RegisterDebugEndpoints(mux)
+	RegisterDebugEndpoints(mux)
+}
+
+// TestRegisterDebugEndpointsRequiresExplicitCall guards against the endpoint coming up on http.DefaultServeMux as
+// a side effect of importing this package; it must only appear after an explicit RegisterDebugEndpoints call.
+func TestRegisterDebugEndpointsRequiresExplicitCall(t *testing.T) {
+	registeredMuxesMu.Lock()
+	alreadyRegistered := registeredMuxes[http.DefaultServeMux]
+	registeredMuxesMu.Unlock()
+	if alreadyRegistered {
+		t.Fatal("http.DefaultServeMux should not be registered until RegisterDebugEndpoints is called explicitly")
+	}
+}